@@ -0,0 +1,18 @@
+//go:build oniguruma
+
+package regexp_named
+
+import "github.com/go-enry/go-oniguruma"
+
+// OnigurumaBackend adapts github.com/go-enry/go-oniguruma (whose importable
+// package name is rubex, not oniguruma) for use with CompileWith, for
+// Ruby-flavored syntax RE2 doesn't support. Build with -tags oniguruma to
+// include it; doing so also requires adding the module to go.mod, since it
+// isn't a dependency of this module otherwise - this file is an untested
+// stub until that's done and someone has verified rubex.Regexp actually
+// satisfies CompiledBackend.
+type OnigurumaBackend struct{}
+
+func (OnigurumaBackend) Compile(pattern string) (CompiledBackend, error) {
+	return rubex.Compile(pattern)
+}
@@ -0,0 +1,17 @@
+//go:build pcre
+
+package regexp_named
+
+import "go.elara.ws/pcre"
+
+// PCREBackend adapts go.elara.ws/pcre for use with CompileWith, for
+// patterns that need lookbehind, atomic groups, or other PCRE syntax RE2
+// can't express. Build with -tags pcre to include it; doing so also
+// requires adding the module to go.mod, since it isn't a dependency of
+// this module otherwise - this file is an untested stub until that's done
+// and someone has verified pcre.Regexp actually satisfies CompiledBackend.
+type PCREBackend struct{}
+
+func (PCREBackend) Compile(pattern string) (CompiledBackend, error) {
+	return pcre.Compile(pattern)
+}
@@ -0,0 +1,230 @@
+package regexp_named
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoMatch is returned by DecodeString and Decode when re does not match
+// the input.
+var ErrNoMatch = errors.New("regexp_named: no match")
+
+// FieldError is returned by DecodeString and Decode when a named capture
+// cannot be converted to the type of the struct field it is decoded into.
+type FieldError struct {
+	Field   string // name of the struct field
+	Capture string // name of the named capture that fed it
+	Err     error  // the underlying conversion error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("regexp_named: field %s (capture %q): %s", e.Field, e.Capture, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// DecodeString matches re against s and populates the fields of the struct
+// pointed to by out from the named captures, one field at a time. A field is
+// matched to a capture by its name, unless it carries an `re:"name"` tag, in
+// which case that name is used instead; a `re:"name,layout=2006-01-02"` tag
+// additionally selects the layout used to parse a time.Time field (the
+// default is time.RFC3339). Supported field kinds are string, []byte, the
+// signed and unsigned integer kinds, the float kinds, bool, time.Time, and
+// a pointer to any of the above, which is left nil when the corresponding
+// capture did not participate in the match.
+//
+// If re does not match s, DecodeString returns ErrNoMatch. If a capture
+// cannot be converted to its field's type, it returns a *FieldError naming
+// the offending field and capture.
+func (re *RegexpNamed) DecodeString(s string, out any) error {
+	idx := re.CompiledBackend.FindStringSubmatchIndex(s)
+	if idx == nil {
+		return ErrNoMatch
+	}
+	return decodeInto(re, idx, func(lo, hi int) string { return s[lo:hi] }, out)
+}
+
+// Decode is the []byte version of DecodeString.
+func (re *RegexpNamed) Decode(b []byte, out any) error {
+	idx := re.CompiledBackend.FindSubmatchIndex(b)
+	if idx == nil {
+		return ErrNoMatch
+	}
+	return decodeInto(re, idx, func(lo, hi int) string { return string(b[lo:hi]) }, out)
+}
+
+// DecodeAllString returns one T per successive match of re in s, each
+// populated the same way as DecodeString. A nil slice is returned if re does
+// not match; decoding stops at the first field that fails to convert.
+func DecodeAllString[T any](re *RegexpNamed, s string) ([]T, error) {
+	indexes := re.CompiledBackend.FindAllStringSubmatchIndex(s, -1)
+	if indexes == nil {
+		return nil, nil
+	}
+	out := make([]T, 0, len(indexes))
+	for _, idx := range indexes {
+		var t T
+		if err := decodeInto(re, idx, func(lo, hi int) string { return s[lo:hi] }, &t); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// decodeInto walks the exported fields of the struct pointed to by out,
+// looking up each one's named capture in idx via re.namedMap and converting
+// it with setField. slice turns a pair of byte offsets into the matched
+// capture's text.
+func decodeInto(re *RegexpNamed, idx []int, slice func(lo, hi int) string, out any) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("regexp_named: Decode: out must be a non-nil pointer to a struct, got %T", out)
+	}
+	elem := v.Elem()
+	t := elem.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		name, layout, hasTag := captureTag(field)
+		pos, ok := re.namedMap[name]
+		if !ok && !hasTag {
+			name, pos, ok = lookupFold(re.namedMap, name)
+		}
+		if !ok {
+			continue
+		}
+		lo, hi := idx[pos*2], idx[pos*2+1]
+		participated := lo >= 0
+		fv := elem.Field(i)
+		if fv.Kind() == reflect.Pointer {
+			if !participated {
+				continue
+			}
+			fv.Set(reflect.New(fv.Type().Elem()))
+			fv = fv.Elem()
+		} else if !participated {
+			continue
+		}
+		if err := setField(fv, slice(lo, hi), layout); err != nil {
+			return &FieldError{Field: field.Name, Capture: name, Err: err}
+		}
+	}
+	return nil
+}
+
+// captureTag returns the capture name and time.Time layout an `re` struct
+// tag selects for field, falling back to the field's own name and
+// time.RFC3339 when the tag, or its layout option, is absent. hasTag reports
+// whether an `re` tag supplied a capture name, so callers can tell a
+// deliberate, exact name from the field-name fallback, which is matched
+// case-insensitively.
+func captureTag(field reflect.StructField) (name string, layout string, hasTag bool) {
+	name, layout = field.Name, time.RFC3339
+	tag, ok := field.Tag.Lookup("re")
+	if !ok {
+		return name, layout, false
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		if l, ok := strings.CutPrefix(opt, "layout="); ok {
+			layout = l
+		}
+	}
+	return name, layout, parts[0] != ""
+}
+
+// lookupFold is the case-insensitive fallback used to match an untagged
+// struct field name, such as Name, against a lowercase capture name, such
+// as name. It returns the capture's own name, not field's, so that callers
+// report the name that actually fed the field.
+func lookupFold(namedMap map[string]int, name string) (capture string, pos int, ok bool) {
+	for k, v := range namedMap {
+		if strings.EqualFold(k, name) {
+			return k, v, true
+		}
+	}
+	return "", 0, false
+}
+
+// setField converts capture to fv's type and stores it there. layout is
+// only consulted for time.Time fields.
+func setField(fv reflect.Value, capture string, layout string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(capture)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported field type %s", fv.Type())
+		}
+		fv.SetBytes([]byte(capture))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(capture)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(capture, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(capture, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(capture, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Struct:
+		if fv.Type() != timeType {
+			return fmt.Errorf("unsupported field type %s", fv.Type())
+		}
+		tm, err := time.Parse(layout, capture)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(tm))
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+// Unmarshal is an alias for DecodeString, for callers who prefer
+// encoding/json-style naming.
+func (re *RegexpNamed) Unmarshal(s string, v any) error {
+	return re.DecodeString(s, v)
+}
+
+// UnmarshalBytes is an alias for Decode, for callers who prefer
+// encoding/json-style naming.
+func (re *RegexpNamed) UnmarshalBytes(b []byte, v any) error {
+	return re.Decode(b, v)
+}
+
+// UnmarshalAll is an alias for DecodeAllString, for callers who prefer
+// encoding/json-style naming.
+func UnmarshalAll[T any](re *RegexpNamed, s string) ([]T, error) {
+	return DecodeAllString[T](re, s)
+}
@@ -0,0 +1,140 @@
+package regexp_named
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDecodeString(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	type person struct {
+		Name string
+		Age  int
+	}
+	var p person
+	if err := re.DecodeString("foo 42", &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "foo" || p.Age != 42 {
+		t.Errorf("got %+v", p)
+	}
+}
+
+func TestDecodeStringTag(t *testing.T) {
+	re := MustCompile(`(?P<n>\w+) on (?P<d>\d{4}-\d{2}-\d{2})`)
+	type event struct {
+		Name string    `re:"n"`
+		When time.Time `re:"d,layout=2006-01-02"`
+	}
+	var e event
+	if err := re.DecodeString("deploy on 2024-05-01", &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "deploy" || !e.When.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %+v", e)
+	}
+}
+
+func TestDecodeStringOptionalPointer(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+)?(?: (?P<age>\d+))?`)
+	type person struct {
+		Name string
+		Age  *int
+	}
+	var p person
+	if err := re.DecodeString("foo", &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Age != nil {
+		t.Errorf("expected Age to stay nil, got %v", *p.Age)
+	}
+}
+
+func TestDecodeStringNoMatch(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	var p struct {
+		Name string
+		Age  int
+	}
+	if err := re.DecodeString("nope", &p); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("expected ErrNoMatch, got %v", err)
+	}
+}
+
+func TestDecodeAllString(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	type person struct {
+		Name string
+		Age  int
+	}
+	people, err := DecodeAllString[person](&re, "foo 42 bar 43")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(people) != 2 || people[0].Name != "foo" || people[1].Age != 43 {
+		t.Errorf("got %+v", people)
+	}
+}
+
+func TestDecodeStringFieldErrorCapture(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\w+)`)
+	type person struct {
+		Name string
+		Age  int
+	}
+	var p person
+	err := re.DecodeString("foo bar", &p)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("expected *FieldError, got %v", err)
+	}
+	if fieldErr.Field != "Age" || fieldErr.Capture != "age" {
+		t.Errorf("got Field=%q Capture=%q, want Field=%q Capture=%q", fieldErr.Field, fieldErr.Capture, "Age", "age")
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	type person struct {
+		Name string
+		Age  int
+	}
+	var p person
+	if err := re.Unmarshal("foo 42", &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "foo" || p.Age != 42 {
+		t.Errorf("got %+v", p)
+	}
+}
+
+func TestUnmarshalBytes(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	type person struct {
+		Name string
+		Age  int
+	}
+	var p person
+	if err := re.UnmarshalBytes([]byte("foo 42"), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "foo" || p.Age != 42 {
+		t.Errorf("got %+v", p)
+	}
+}
+
+func TestUnmarshalAll(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	type person struct {
+		Name string
+		Age  int
+	}
+	people, err := UnmarshalAll[person](&re, "foo 42 bar 43")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(people) != 2 || people[0].Name != "foo" || people[1].Age != 43 {
+		t.Errorf("got %+v", people)
+	}
+}
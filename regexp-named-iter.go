@@ -0,0 +1,92 @@
+package regexp_named
+
+import "iter"
+
+// AllStringNamed returns an iterator over successive matches of re in s,
+// each paired with its named captures as in FindStringNamed. The search
+// itself runs up front via FindAllStringSubmatchIndex, same as
+// FindAllStringNamed; Go's regexp API has no offset-aware way to find one
+// match at a time without re-slicing s and corrupting \b/^/$ context, so
+// this can't be a true incremental search. What a caller that ranges over
+// it and breaks early does save is the per-match string slicing and named
+// map construction for every match after the one it stopped at.
+func (re *RegexpNamed) AllStringNamed(s string) iter.Seq2[string, map[string]string] {
+	return func(yield func(string, map[string]string) bool) {
+		for idx := range allStringIndexes(re, s) {
+			groups := namedGroupsAt(re, idx, func(lo, hi int) string { return s[lo:hi] })
+			if !yield(s[idx[0]:idx[1]], groups) {
+				return
+			}
+		}
+	}
+}
+
+// AllStringIndexNamed is the index-returning counterpart of AllStringNamed;
+// the same eager-search, lazy-conversion tradeoff applies.
+func (re *RegexpNamed) AllStringIndexNamed(s string) iter.Seq2[[]int, map[string][]int] {
+	return func(yield func([]int, map[string][]int) bool) {
+		for idx := range allStringIndexes(re, s) {
+			groups := namedGroupsAt(re, idx, func(lo, hi int) []int { return []int{lo, hi} })
+			if !yield(idx[0:2], groups) {
+				return
+			}
+		}
+	}
+}
+
+// AllNamed is the []byte version of AllStringNamed; the same eager-search,
+// lazy-conversion tradeoff applies.
+func (re *RegexpNamed) AllNamed(b []byte) iter.Seq2[[]byte, map[string][]byte] {
+	return func(yield func([]byte, map[string][]byte) bool) {
+		for idx := range allByteIndexes(re, b) {
+			groups := namedGroupsAt(re, idx, func(lo, hi int) []byte { return b[lo:hi] })
+			if !yield(b[idx[0]:idx[1]], groups) {
+				return
+			}
+		}
+	}
+}
+
+// AllIndexNamed is the index-returning counterpart of AllNamed; the same
+// eager-search, lazy-conversion tradeoff applies.
+func (re *RegexpNamed) AllIndexNamed(b []byte) iter.Seq2[[]int, map[string][]int] {
+	return func(yield func([]int, map[string][]int) bool) {
+		for idx := range allByteIndexes(re, b) {
+			groups := namedGroupsAt(re, idx, func(lo, hi int) []int { return []int{lo, hi} })
+			if !yield(idx[0:2], groups) {
+				return
+			}
+		}
+	}
+}
+
+// allStringIndexes yields the submatch index slice of each successive match
+// of re in s, in order. It delegates the search itself to
+// FindAllStringSubmatchIndex rather than re-searching s[pos:] one match at a
+// time: slicing s at each match's end would make context-dependent
+// assertions like \b, ^, and $ see a truncated string and evaluate against
+// the wrong position, instead of the original s. A consumer that breaks out
+// of the range early still skips the []int-to-map conversion and the yield
+// for every match after the one it stopped at; only the index search itself
+// is not lazy, matching what FindAllStringSubmatchIndex already does
+// internally.
+func allStringIndexes(re *RegexpNamed, s string) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for _, m := range re.CompiledBackend.FindAllStringSubmatchIndex(s, -1) {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+// allByteIndexes is the []byte version of allStringIndexes.
+func allByteIndexes(re *RegexpNamed, b []byte) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for _, m := range re.CompiledBackend.FindAllSubmatchIndex(b, -1) {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
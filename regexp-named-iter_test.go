@@ -0,0 +1,68 @@
+package regexp_named
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestAllStringIndexNamedWordBoundary guards against re-searching s[pos:]
+// on each step of the iterator, which would make \b (and ^, $, lookaround)
+// evaluate against a truncated string instead of the original one.
+func TestAllStringIndexNamedWordBoundary(t *testing.T) {
+	re := MustCompile(`\b`)
+	s := "foo bar"
+
+	want, _ := re.FindAllStringIndexNamed(s, -1)
+	var got [][]int
+	for idx := range re.AllStringIndexNamed(s) {
+		got = append(got, idx)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAllStringNamedBreaksEarly(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	count := 0
+	for range re.AllStringNamed("foo 42 bar 43 baz 44") {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected to stop after 2 matches, got %d", count)
+	}
+}
+
+// TestAllIndexNamedWordBoundary guards against re-searching b[pos:] on each
+// step of the iterator, which would make \b (and ^, $, lookaround) evaluate
+// against a truncated slice instead of the original one.
+func TestAllIndexNamedWordBoundary(t *testing.T) {
+	re := MustCompile(`\b`)
+	b := []byte("foo bar")
+
+	want, _ := re.FindAllIndexNamed(b, -1)
+	var got [][]int
+	for idx := range re.AllIndexNamed(b) {
+		got = append(got, idx)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAllNamedBreaksEarly(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	count := 0
+	for range re.AllNamed([]byte("foo 42 bar 43 baz 44")) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected to stop after 2 matches, got %d", count)
+	}
+}
@@ -34,109 +34,196 @@
 package regexp_named
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"regexp"
+	"regexp/syntax"
+	"sort"
 	"strconv"
+	"strings"
 	"unicode"
-	"unicode/utf8"
 )
 
+// CompiledBackend is a single pattern compiled by a Backend; it is the
+// matching surface RegexpNamed needs from an engine. *regexp.Regexp already
+// implements it, which is how Compile and MustCompile use the standard
+// library without any adapter code.
+type CompiledBackend interface {
+	FindSubmatch(b []byte) [][]byte
+	FindSubmatchIndex(b []byte) []int
+	FindAllSubmatch(b []byte, n int) [][][]byte
+	FindAllSubmatchIndex(b []byte, n int) [][]int
+	FindStringSubmatch(s string) []string
+	FindStringSubmatchIndex(s string) []int
+	FindAllStringSubmatch(s string, n int) [][]string
+	FindAllStringSubmatchIndex(s string, n int) [][]int
+	SubexpNames() []string
+	NumSubexp() int
+}
+
+// Backend compiles patterns for a particular regular-expression engine, for
+// use with CompileWith. StdlibBackend adapts the standard regexp package,
+// the same engine Compile and MustCompile compile with.
+type Backend interface {
+	Compile(pattern string) (CompiledBackend, error)
+}
+
+// StdlibBackend is the Backend for the standard regexp package, the engine
+// Compile and MustCompile call directly.
+type StdlibBackend struct{}
+
+func (StdlibBackend) Compile(pattern string) (CompiledBackend, error) {
+	return regexp.Compile(pattern)
+}
+
+// RegexpNamed embeds both CompiledBackend, so its Find*Named methods work
+// with any engine plugged in through CompileWith, and *regexp.Regexp, so
+// every method of the standard library type - MatchString, ReplaceAll,
+// Split, String, and the rest - stays available on values built through
+// Compile, MustCompile or FromRegexp. The methods the two embeds share,
+// such as FindStringSubmatch and SubexpNames, are ambiguous on RegexpNamed
+// itself and are reached through re.CompiledBackend or re.Regexp. A
+// RegexpNamed built through
+// CompileWith with a non-stdlib Backend has a nil *regexp.Regexp embed: its
+// own named-match methods still work, but calling a plain *regexp.Regexp
+// method on it panics, the same as calling a method through a nil pointer
+// anywhere else.
 type RegexpNamed struct {
-	namedMap map[string]int
+	namedMap  map[string]int
+	nameSlots []nameSlot
+	CompiledBackend
 	*regexp.Regexp
 }
 
+// stdlibRegexp returns cb if it happens to be backed by the standard
+// library's *regexp.Regexp, so a RegexpNamed built through CompileWith can
+// keep *regexp.Regexp's promoted methods whenever the backend returns a
+// plain *regexp.Regexp, as StdlibBackend does. A wrapper type around a
+// *regexp.Regexp is not unwrapped, so it yields nil.
+func stdlibRegexp(cb CompiledBackend) *regexp.Regexp {
+	re, _ := cb.(*regexp.Regexp)
+	return re
+}
+
+// nameSlot is one entry of a RegexpNamed's precomputed, index-sorted name
+// table, used by the Into/Func variants below to walk the capture groups
+// of a match without allocating or ranging over namedMap.
+type nameSlot struct {
+	name      string
+	subexpIdx int
+}
+
+// nameSlotsFromMap precomputes a nameSlot table from namedMap, sorted by
+// subexpression index.
+func nameSlotsFromMap(namedMap map[string]int) []nameSlot {
+	slots := make([]nameSlot, 0, len(namedMap))
+	for name, idx := range namedMap {
+		slots = append(slots, nameSlot{name, idx})
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].subexpIdx < slots[j].subexpIdx })
+	return slots
+}
+
 const UnnamedCapture = string(unicode.ReplacementChar)
 
-var reNamedMatch = regexp.MustCompile(`^\?P\<(.*?)\>`)
-var reNoCaptureMatch = regexp.MustCompile(`^\?:`)
-
-// parseBytes parses a regular expression, returning a slice
-// of strings containing the names of the groups in the regular
-// expression:
-//   - If the i'th group is named, its name is returned in the
-//     i position of the slice.
-//   - If the i'th group is not named, the i position of the
-//     slice is set to UnnamedCapture.
-//   - Non capturing groups are ignored.
+// captureNames walks the parsed syntax tree of a regular expression,
+// appending to names the name of each OpCapture node it finds, in
+// left-to-right order:
+//   - If the i'th group is named, its name is appended.
+//   - If the i'th group is not named, UnnamedCapture is appended instead.
+//   - Non capturing groups are not OpCapture nodes, so they are ignored.
 //
-// If the length of the input is 0, nil is returned.
-// If the regular expression is malformed (invalid rune found or
-// the regexp ends in a backslash), an error is returned.
-//
-// Examples:
-//
-//	parseBytes([]byte(`(?P<name>\w+) (?P<age>\d+)`), 0)
-//
-// will return
-//
-//	[]string{"name", "age"}, nil
-//
-// while
-//
-//	parseBytes([]byte(`(?P<name>\w+) (?:\w+) (\d+)`), 0)
-//
-//	will return
-//
-//	[]string{name, UnnamedCapture}, nil
-func parseBytes(input []byte) ([]string, error) {
-	if len(input) == 0 {
-		return nil, nil
-	}
-	nextrun, runlen := utf8.DecodeRune(input)
-	// Advance input to next runee
-	input = input[runlen:]
-	switch nextrun {
-	case '\\':
-		// Scape character, skip next rune
-		if len(input) == 0 {
-			return nil, errors.New("error parsing named regexp: trailing backslash at end of expression")
-		}
-		if nextrun, runlen := utf8.DecodeRune(input); nextrun != utf8.RuneError {
-			// effectively skip next rune
-			input = input[runlen:]
-		} else {
-			return nil, errors.New("error parsing named regexp: incorrect rune after backslash")
-		}
-		return parseBytes(input)
-	case '(':
-		var groupName []string
-		if m := reNamedMatch.FindSubmatchIndex(input); m != nil {
-			// Named pattern ?P<name>
-			// return name of the group found
-			groupName = []string{string(input[m[2]:m[3]])}
-			// skip "?p<name>", "(" already skipped
-			input = input[m[3]+1:]
-		} else if m := reNoCaptureMatch.FindSubmatchIndex(input); m != nil {
-			// no capturing group
-			// nothing to return
-			// skip "?:", "(" already skipped
-			input = input[m[1]+1:]
+// Because the tree comes from regexp/syntax.Parse, constructs a hand-rolled
+// scanner would have to special-case - parens inside character classes,
+// flag groups such as (?i:...) or (?flags) - are already resolved by the
+// parser and never produce a spurious capture.
+func captureNames(re *syntax.Regexp, names []string) []string {
+	if re.Op == syntax.OpCapture {
+		if re.Name != "" {
+			names = append(names, re.Name)
 		} else {
-			// capture with no name
-			// return unnamedCapture
-			groupName = []string{UnnamedCapture}
-			// "(" already skipped
-		}
-		// Parse the rest
-		if recursiveResult, err := parseBytes(input); err == nil {
-			// Prepend the named match found to the rest of the named groups names
-			// that are parsed recursively
-			return append(groupName, recursiveResult...), nil
-		} else {
-			return nil, err
+			names = append(names, UnnamedCapture)
 		}
+	}
+	for _, sub := range re.Sub {
+		names = captureNames(sub, names)
+	}
+	return names
+}
+
+var (
+	// ErrTrailingBackslash is returned by Compile when the pattern ends in
+	// a backslash with nothing left to escape.
+	ErrTrailingBackslash = errors.New("regexp_named: trailing backslash at end of expression")
+
+	// ErrInvalidRuneAfterBackslash is returned by Compile when a backslash
+	// in the pattern is not followed by a valid rune to escape.
+	ErrInvalidRuneAfterBackslash = errors.New("regexp_named: invalid rune after backslash")
+
+	// ErrDuplicateName is returned by Compile when two capture groups
+	// declare the same name. The error is always a *DuplicateNameError;
+	// the sentinel exists so callers can check for it with errors.Is.
+	ErrDuplicateName = errors.New("regexp_named: duplicate named group")
+)
+
+// DuplicateNameError reports that a pattern declares the same named
+// capture group twice. It wraps ErrDuplicateName, so
+// errors.Is(err, ErrDuplicateName) succeeds for it.
+type DuplicateNameError struct {
+	Name string // the duplicated capture name
+	Pos  int    // byte offset of the second declaration in the pattern, or -1 if not found
+}
+
+func (e *DuplicateNameError) Error() string {
+	return fmt.Sprintf("regexp_named: duplicate named group %q at byte %d", e.Name, e.Pos)
+}
+
+func (e *DuplicateNameError) Unwrap() error {
+	return ErrDuplicateName
+}
+
+// duplicateNamePos returns the byte offset, in source, of the second
+// declaration of a (?P<name>...) group, or -1 if it can't be located.
+func duplicateNamePos(source, name string) int {
+	marker := "(?P<" + name + ">"
+	first := strings.Index(source, marker)
+	if first < 0 {
+		return -1
+	}
+	second := strings.Index(source[first+len(marker):], marker)
+	if second < 0 {
+		return -1
+	}
+	return first + len(marker) + second
+}
+
+// translateSyntaxError maps the subset of regexp/syntax's parse failures
+// that this package used to detect with its own hand-rolled scanner -
+// trailing backslashes and invalid escapes - onto the exported sentinels,
+// so callers that only know this package's errors don't also need to
+// reach for regexp/syntax.
+func translateSyntaxError(err error) error {
+	var synErr *syntax.Error
+	if !errors.As(err, &synErr) {
+		return err
+	}
+	switch synErr.Code {
+	case syntax.ErrTrailingBackslash:
+		return ErrTrailingBackslash
+	case syntax.ErrInvalidEscape:
+		return ErrInvalidRuneAfterBackslash
 	default:
-		return parseBytes(input)
+		return err
 	}
 }
 
-func buildMap(namedMatches []string) (map[string]int, error) {
+func buildMap(source string, namedMatches []string) (map[string]int, error) {
 	r := make(map[string]int)
 	for i, name := range namedMatches {
 		if name != UnnamedCapture {
 			if _, ok := r[name]; ok {
-				return nil, errors.New("error parsing named regexp: duplicate named group")
+				return nil, &DuplicateNameError{Name: name, Pos: duplicateNamePos(source, name)}
 			}
 			r[name] = i + 1
 		}
@@ -163,17 +250,22 @@ func buildMap(namedMatches []string) (map[string]int, error) {
 func Compile(re string) (RegexpNamed, error) {
 	compiledRe, err := regexp.Compile(re)
 	if err != nil {
-		return RegexpNamed{nil, nil}, err
+		return RegexpNamed{}, translateSyntaxError(err)
 	}
-	if parsed, err := parseBytes([]byte(re)); err != nil {
-		return RegexpNamed{nil, nil}, err
-	} else {
-		if map_, err := buildMap(parsed); err != nil {
-			return RegexpNamed{nil, nil}, err
-		} else {
-			return RegexpNamed{map_, compiledRe}, nil
-		}
+	parsed, err := syntax.Parse(re, syntax.Perl)
+	if err != nil {
+		return RegexpNamed{}, translateSyntaxError(err)
 	}
+	map_, err := buildMap(re, captureNames(parsed, nil))
+	if err != nil {
+		return RegexpNamed{}, err
+	}
+	return RegexpNamed{
+		namedMap:        map_,
+		nameSlots:       nameSlotsFromMap(map_),
+		CompiledBackend: compiledRe,
+		Regexp:          compiledRe,
+	}, nil
 }
 
 // MustCompile is like Compile but panics if the expression cannot be parsed.
@@ -187,6 +279,56 @@ func MustCompile(re string) RegexpNamed {
 	return r
 }
 
+// FromRegexp adapts an already-compiled *regexp.Regexp into a RegexpNamed,
+// for callers who compiled with flags, longest-match mode, or
+// regexp.CompilePOSIX and want the named-map methods without recompiling
+// the pattern from source. If compiledRe has duplicate subexpression
+// names, the later one wins.
+func FromRegexp(compiledRe *regexp.Regexp) RegexpNamed {
+	namedMap := namedMapFromSubexpNames(compiledRe.SubexpNames())
+	return RegexpNamed{
+		namedMap:        namedMap,
+		nameSlots:       nameSlotsFromMap(namedMap),
+		CompiledBackend: compiledRe,
+		Regexp:          compiledRe,
+	}
+}
+
+// CompileWith compiles pattern with backend instead of the standard regexp
+// package, for syntax RE2 can't express - lookbehind, atomic groups, or
+// other Perl/Oniguruma-flavored constructs. Named captures are discovered
+// from the compiled pattern's SubexpNames rather than by parsing pattern
+// ourselves, since only regexp/syntax understands RE2 syntax; as a result,
+// unlike Compile, a pattern with a duplicate name does not fail CompileWith
+// - the later declaration simply wins.
+func CompileWith(backend Backend, pattern string) (RegexpNamed, error) {
+	compiled, err := backend.Compile(pattern)
+	if err != nil {
+		return RegexpNamed{}, err
+	}
+	namedMap := namedMapFromSubexpNames(compiled.SubexpNames())
+	return RegexpNamed{
+		namedMap:        namedMap,
+		nameSlots:       nameSlotsFromMap(namedMap),
+		CompiledBackend: compiled,
+		Regexp:          stdlibRegexp(compiled),
+	}, nil
+}
+
+// namedMapFromSubexpNames builds a namedMap straight from a compiled
+// pattern's SubexpNames, skipping the unnamed whole match at index 0 and
+// any unnamed group.
+func namedMapFromSubexpNames(subexpNames []string) map[string]int {
+	namedMap := make(map[string]int, len(subexpNames))
+	for i, name := range subexpNames {
+		if i == 0 || name == "" {
+			continue
+		}
+		namedMap[name] = i
+	}
+	return namedMap
+}
+
 func quote(s string) string {
 	if strconv.CanBackquote(s) {
 		return "`" + s + "`"
@@ -237,7 +379,7 @@ func mapReAll[T, S any](re *RegexpNamed, matches [][]T, composeFunc func([]T, in
 // If there are no matches, nil is returned.
 // See (*Regexp).FindSubmatch for a description of the return value.
 func (re *RegexpNamed) FindNamed(s []byte) ([]byte, map[string][]byte) {
-	return mapRe(re, re.FindSubmatch(s), getResult)
+	return mapRe(re, re.CompiledBackend.FindSubmatch(s), getResult)
 }
 
 // FindIndexNamed returns a map of named index pairs identifying the
@@ -246,7 +388,7 @@ func (re *RegexpNamed) FindNamed(s []byte) ([]byte, map[string][]byte) {
 // If there are no matches, nil is returned.
 // See (*Regexp).FindSubmatchIndex for a description of the return value.
 func (re *RegexpNamed) FindIndexNamed(s []byte) ([]int, map[string][]int) {
-	return mapRe(re, re.FindSubmatchIndex(s), getResultIndex)
+	return mapRe(re, re.CompiledBackend.FindSubmatchIndex(s), getResultIndex)
 }
 
 // FindStringNamed returns a map of named submatches matched by re in s.
@@ -254,7 +396,7 @@ func (re *RegexpNamed) FindIndexNamed(s []byte) ([]int, map[string][]int) {
 // If there are no matches, nil is returned.
 // See (*Regexp).FindStringSubmatch for a description of the return value.
 func (re *RegexpNamed) FindStringNamed(s string) (string, map[string]string) {
-	return mapRe(re, re.FindStringSubmatch(s), getResult)
+	return mapRe(re, re.CompiledBackend.FindStringSubmatch(s), getResult)
 }
 
 // FindStringIndexNamed returns a map of named index pairs identifying the
@@ -263,7 +405,7 @@ func (re *RegexpNamed) FindStringNamed(s string) (string, map[string]string) {
 // If there are no matches, nil is returned.
 // See (*Regexp).FindStringSubmatchIndex for a description of the return value.
 func (re *RegexpNamed) FindStringIndexNamed(s string) ([]int, map[string][]int) {
-	return mapRe(re, re.FindStringSubmatchIndex(s), getResultIndex)
+	return mapRe(re, re.CompiledBackend.FindStringSubmatchIndex(s), getResultIndex)
 }
 
 // FindAllNamed is the 'All' version of FindNamed; it returns a slice of all
@@ -272,7 +414,7 @@ func (re *RegexpNamed) FindStringIndexNamed(s string) ([]int, map[string][]int)
 // A return value of nil indicates no match.
 // See (*Regexp).FindAllSubmatch for a description of the return value.
 func (re *RegexpNamed) FindAllNamed(b []byte, n int) ([][]byte, []map[string][]byte) {
-	return mapReAll(re, re.FindAllSubmatch(b, n), getResult)
+	return mapReAll(re, re.CompiledBackend.FindAllSubmatch(b, n), getResult)
 }
 
 // FindAllIndexNamed is the 'All' version of FindIndexNamed; it returns a slice
@@ -282,7 +424,7 @@ func (re *RegexpNamed) FindAllNamed(b []byte, n int) ([][]byte, []map[string][]b
 // A return value of nil indicates no match.
 // See (*Regexp).FindAllSubmatchIndex for a description of the return value.
 func (re *RegexpNamed) FindAllIndexNamed(b []byte, n int) ([][]int, []map[string][]int) {
-	return mapReAll(re, re.FindAllSubmatchIndex(b, n), getResultIndex)
+	return mapReAll(re, re.CompiledBackend.FindAllSubmatchIndex(b, n), getResultIndex)
 }
 
 // FindAllStringNamed is the 'All' version of FindStringNamed; it returns a
@@ -291,7 +433,7 @@ func (re *RegexpNamed) FindAllIndexNamed(b []byte, n int) ([][]int, []map[string
 // A return value of nil indicates no match.
 // See (*Regexp).FindAllStringSubmatch for a description of the return value.
 func (re *RegexpNamed) FindAllStringNamed(s string, n int) ([]string, []map[string]string) {
-	return mapReAll(re, re.FindAllStringSubmatch(s, n), getResult)
+	return mapReAll(re, re.CompiledBackend.FindAllStringSubmatch(s, n), getResult)
 }
 
 // FindAllStringIndexNamed is the 'All' version of FindStringIndexNamed; it
@@ -301,5 +443,201 @@ func (re *RegexpNamed) FindAllStringNamed(s string, n int) ([]string, []map[stri
 // A return value of nil indicates no match.
 // See (*Regexp).FindAllStringSubmatchIndex for a description of the return value.
 func (re *RegexpNamed) FindAllStringIndexNamed(s string, n int) ([][]int, []map[string][]int) {
-	return mapReAll(re, re.FindAllStringSubmatchIndex(s, n), getResultIndex)
+	return mapReAll(re, re.CompiledBackend.FindAllStringSubmatchIndex(s, n), getResultIndex)
+}
+
+// namedGroupsAt builds the map of named submatches for the match whose
+// submatch index pairs start at idx, resolving each group through
+// re.namedMap. Unmatched groups are reported as the zero value of S.
+func namedGroupsAt[S any](re *RegexpNamed, idx []int, slice func(lo, hi int) S) map[string]S {
+	groups := make(map[string]S, len(re.namedMap))
+	for name, pos := range re.namedMap {
+		lo, hi := idx[pos*2], idx[pos*2+1]
+		if lo < 0 {
+			groups[name] = *new(S)
+			continue
+		}
+		groups[name] = slice(lo, hi)
+	}
+	return groups
+}
+
+// ReplaceAllStringNamedFunc returns a copy of src in which all matches of re
+// have been replaced by the return value of repl, called with the matched
+// text and its named submatches as a map[string]string. As with
+// FindStringNamed, a group that did not participate in the match is reported
+// as an empty string.
+func (re *RegexpNamed) ReplaceAllStringNamedFunc(src string, repl func(match string, groups map[string]string) string) string {
+	indexes := re.CompiledBackend.FindAllStringSubmatchIndex(src, -1)
+	if indexes == nil {
+		return src
+	}
+	var buf strings.Builder
+	last := 0
+	for _, idx := range indexes {
+		buf.WriteString(src[last:idx[0]])
+		groups := namedGroupsAt(re, idx, func(lo, hi int) string { return src[lo:hi] })
+		buf.WriteString(repl(src[idx[0]:idx[1]], groups))
+		last = idx[1]
+	}
+	buf.WriteString(src[last:])
+	return buf.String()
+}
+
+// ReplaceAllNamedFunc is the []byte version of ReplaceAllStringNamedFunc.
+func (re *RegexpNamed) ReplaceAllNamedFunc(src []byte, repl func(match []byte, groups map[string][]byte) []byte) []byte {
+	indexes := re.CompiledBackend.FindAllSubmatchIndex(src, -1)
+	if indexes == nil {
+		return src
+	}
+	var buf bytes.Buffer
+	last := 0
+	for _, idx := range indexes {
+		buf.Write(src[last:idx[0]])
+		groups := namedGroupsAt(re, idx, func(lo, hi int) []byte { return src[lo:hi] })
+		buf.Write(repl(src[idx[0]:idx[1]], groups))
+		last = idx[1]
+	}
+	buf.Write(src[last:])
+	return buf.Bytes()
+}
+
+// AppendNamed finds the first match of re in src and appends the result of
+// expanding template against its named captures to dst, returning the
+// extended buffer. dst is returned unchanged if re does not match.
+func (re *RegexpNamed) AppendNamed(dst []byte, template []byte, src []byte) []byte {
+	match, groups := re.FindNamed(src)
+	if groups == nil {
+		return dst
+	}
+	return ExpandNamed(dst, template, match, groups)
+}
+
+// ExpandNamed is like (*regexp.Regexp).Expand, but resolves $name and
+// ${name} references directly against groups instead of a submatch index
+// slice. $0 and ${0} expand to src, the whole matched text. Any other
+// numeric reference such as $1 is looked up as the string "1" in groups,
+// so a caller that builds groups with numeric keys gets the same behaviour
+// as plain regexp.Expand. $$ is replaced by a literal $.
+func ExpandNamed(dst []byte, template []byte, src []byte, groups map[string][]byte) []byte {
+	for len(template) > 0 {
+		i := bytes.IndexByte(template, '$')
+		if i < 0 {
+			break
+		}
+		dst = append(dst, template[:i]...)
+		template = template[i+1:]
+		if len(template) > 0 && template[0] == '$' {
+			dst = append(dst, '$')
+			template = template[1:]
+			continue
+		}
+		name, rest, ok := extractExpandName(template)
+		if !ok {
+			dst = append(dst, '$')
+			continue
+		}
+		template = rest
+		if name == "0" {
+			dst = append(dst, src...)
+		} else {
+			dst = append(dst, groups[name]...)
+		}
+	}
+	return append(dst, template...)
+}
+
+// extractExpandName extracts a $name or ${name} reference (the leading $ is
+// assumed already consumed) from the start of template, returning the name
+// and the remaining template.
+func extractExpandName(template []byte) (name string, rest []byte, ok bool) {
+	if len(template) == 0 {
+		return "", template, false
+	}
+	if template[0] == '{' {
+		end := bytes.IndexByte(template, '}')
+		if end < 0 {
+			return "", template, false
+		}
+		return string(template[1:end]), template[end+1:], true
+	}
+	i := 0
+	for i < len(template) && isExpandNameByte(template[i]) {
+		i++
+	}
+	if i == 0 {
+		return "", template, false
+	}
+	return string(template[:i]), template[i:], true
+}
+
+func isExpandNameByte(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// FindStringNamedInto is the map-reusing variant of FindStringNamed: instead
+// of allocating a new map[string]string on every call, it fills dst, which
+// the caller is expected to reuse across calls in a hot loop such as
+// scanning a log file. It walks re.nameSlots rather than ranging over
+// re.namedMap, so the iteration order, like the allocation, is fixed ahead
+// of time rather than redone on every match. dst is left untouched if re
+// does not match s; ok reports whether it matched.
+func (re *RegexpNamed) FindStringNamedInto(s string, dst map[string]string) (whole string, ok bool) {
+	idx := re.CompiledBackend.FindStringSubmatchIndex(s)
+	if idx == nil {
+		return "", false
+	}
+	for _, slot := range re.nameSlots {
+		lo, hi := idx[slot.subexpIdx*2], idx[slot.subexpIdx*2+1]
+		if lo < 0 {
+			dst[slot.name] = ""
+		} else {
+			dst[slot.name] = s[lo:hi]
+		}
+	}
+	return s[idx[0]:idx[1]], true
+}
+
+// NamedGroups is a read-only view over a single match's named captures,
+// backed directly by the flat submatch index slice a match produced,
+// instead of the map[string]string FindStringNamed allocates for every
+// match.
+type NamedGroups struct {
+	re  *RegexpNamed
+	s   string
+	idx []int
+}
+
+// Get returns the text captured by the named group called name and
+// whether it participated in the match. ok is false both when name isn't
+// one of re's capture groups and when the group didn't match.
+func (g NamedGroups) Get(name string) (text string, ok bool) {
+	pos, ok := g.re.namedMap[name]
+	if !ok {
+		return "", false
+	}
+	lo, hi := g.idx[pos*2], g.idx[pos*2+1]
+	if lo < 0 {
+		return "", false
+	}
+	return g.s[lo:hi], true
+}
+
+// Len returns the number of named capture groups in g's regexp.
+func (g NamedGroups) Len() int {
+	return len(g.re.nameSlots)
+}
+
+// FindAllStringNamedFunc calls fn for each of the first n matches of re in
+// s (or every match, if n < 0), passing the whole match and a NamedGroups
+// view over its captures, stopping early if fn returns false. Unlike
+// FindAllStringNamed, no map and no matched strings are built per match;
+// the submatch index slices of all matches are still found up front via
+// FindAllStringSubmatchIndex, before fn is first called.
+func (re *RegexpNamed) FindAllStringNamedFunc(s string, n int, fn func(whole string, groups NamedGroups) bool) {
+	for _, idx := range re.CompiledBackend.FindAllStringSubmatchIndex(s, n) {
+		if !fn(s[idx[0]:idx[1]], NamedGroups{re, s, idx}) {
+			return
+		}
+	}
 }
@@ -0,0 +1,27 @@
+package regexp_named
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompileDuplicateName(t *testing.T) {
+	_, err := Compile(`(?P<name>\w+) (?P<name>\d+)`)
+	var dupErr *DuplicateNameError
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("expected *DuplicateNameError, got %v", err)
+	}
+	if !errors.Is(err, ErrDuplicateName) {
+		t.Error("expected errors.Is(err, ErrDuplicateName) to succeed")
+	}
+	if dupErr.Name != "name" {
+		t.Errorf("expected duplicated name to be \"name\", got %q", dupErr.Name)
+	}
+}
+
+func TestCompileTrailingBackslash(t *testing.T) {
+	_, err := Compile(`(?P<name>\w+)\`)
+	if !errors.Is(err, ErrTrailingBackslash) {
+		t.Errorf("expected ErrTrailingBackslash, got %v", err)
+	}
+}
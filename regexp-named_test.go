@@ -0,0 +1,506 @@
+package regexp_named
+
+import (
+	"regexp"
+	"testing"
+)
+
+// TestPromotedRegexpMethods guards against RegexpNamed losing access to the
+// standard library methods it embeds *regexp.Regexp for - MatchString,
+// ReplaceAllString, Split, FindString, String and Longest - which would
+// otherwise only be reachable through the narrower CompiledBackend surface.
+func TestPromotedRegexpMethods(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	if !re.MatchString("foo 42") {
+		t.Error("expected MatchString to report a match")
+	}
+	if got := re.ReplaceAllString("foo 42", "x"); got != "x" {
+		t.Errorf("ReplaceAllString: got %q", got)
+	}
+	if got := re.Split("foo 42;bar 43", -1); !sliceEq(got, []string{"", ";", ""}) {
+		t.Errorf("Split: got %v", got)
+	}
+	if got := re.FindString("foo 42"); got != "foo 42" {
+		t.Errorf("FindString: got %q", got)
+	}
+	if re.String() != `(?P<name>\w+) (?P<age>\d+)` {
+		t.Errorf("String: got %q", re.String())
+	}
+	re.Longest()
+}
+
+func TestFindStringNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	if m0, m := re.FindStringNamed("foo 42"); m == nil {
+		t.Error("Expected match")
+	} else {
+		if m0 != "foo 42" {
+			t.Error("Expected match to be foo 42")
+		}
+		if m["name"] != "foo" {
+			t.Error("Expected name to be foo")
+		}
+		if m["age"] != "42" {
+			t.Error("Expected age to be 42")
+		}
+	}
+}
+
+func TestFindAllStringNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+)? (?P<age>\d+)`)
+	if m0, m := re.FindAllStringNamed("foo 42 43", -1); m == nil {
+		t.Error("Expected match")
+	} else {
+		if m0[0] != "foo 42" {
+			t.Error("Expected match to be foo 42")
+		}
+		if m[0]["name"] != "foo" {
+			t.Error("Expected name to be foo")
+		}
+		if m[0]["age"] != "42" {
+			t.Error("Expected age to be 42")
+		}
+		if m0[1] != " 43" {
+			t.Error("Expected match to be  43")
+		}
+		if m[1]["name"] != "" {
+			t.Error("Expected name to be empty")
+		}
+		if m[1]["age"] != "43" {
+			t.Error("Expected age to be 43")
+		}
+	}
+}
+
+func TestFindNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	if m0, m := re.FindNamed([]byte("foo 42")); m == nil {
+		t.Error("Expected match")
+	} else {
+		if string(m0) != "foo 42" {
+			t.Error("Expected match to be foo 42")
+		}
+		if string(m["name"]) != "foo" {
+			t.Error("Expected name to be foo")
+		}
+		if string(m["age"]) != "42" {
+			t.Error("Expected age to be 42")
+		}
+	}
+}
+
+func TestFindAllNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	if m0, m := re.FindAllNamed([]byte("foo 42 bar 43"), -1); m == nil {
+		t.Error("Expected match")
+	} else {
+		if string(m0[0]) != "foo 42" {
+			t.Error("Expected match to be foo 42")
+		}
+		if string(m[0]["name"]) != "foo" {
+			t.Error("Expected name to be foo")
+		}
+		if string(m[0]["age"]) != "42" {
+			t.Error("Expected age to be 42")
+		}
+		if string(m0[1]) != "bar 43" {
+			t.Error("Expected match to be bar 43")
+		}
+		if string(m[1]["name"]) != "bar" {
+			t.Error("Expected name to be bar")
+		}
+		if string(m[1]["age"]) != "43" {
+			t.Error("Expected age to be 43")
+		}
+	}
+}
+
+func sliceEq[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFindIndexNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	if m0, m := re.FindIndexNamed([]byte("foo 42")); m == nil {
+		t.Error("Expected match")
+	} else {
+		if !sliceEq(m0, []int{0, 6}) {
+			t.Error("Expected match to be {0, 6}")
+		}
+		if !sliceEq(m["name"], []int{0, 3}) {
+			t.Error("Expected name to be {0, 3}")
+		}
+		if !sliceEq(m["age"], []int{4, 6}) {
+			t.Error("Expected age to be {4, 6}")
+		}
+	}
+}
+
+func TestFindStringIndexNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	if m0, m := re.FindStringIndexNamed("foo 42"); m == nil {
+		t.Error("Expected match")
+	} else {
+		if !sliceEq(m0, []int{0, 6}) {
+			t.Error("Expected match to be {0, 6}")
+		}
+		if !sliceEq(m["name"], []int{0, 3}) {
+			t.Error("Expected name to be {0, 3}")
+		}
+		if !sliceEq(m["age"], []int{4, 6}) {
+			t.Error("Expected age to be {4, 6}")
+		}
+	}
+}
+
+func TestFindAllIndexNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	if m0, m := re.FindAllIndexNamed([]byte("foo 42 bar 43"), -1); m == nil {
+		t.Error("Expected match")
+	} else {
+		if !sliceEq(m0[0], []int{0, 6}) {
+			t.Error("Expected match to be {0, 6}")
+		}
+		if !sliceEq(m[0]["name"], []int{0, 3}) {
+			t.Error("Expected name to be {0, 3}")
+		}
+		if !sliceEq(m[0]["age"], []int{4, 6}) {
+			t.Error("Expected age to be {4, 6}")
+		}
+		if !sliceEq(m0[1], []int{7, 13}) {
+			t.Error("Expected match to be {7, 13}")
+		}
+		if !sliceEq(m[1]["name"], []int{7, 10}) {
+			t.Error("Expected name to be {7, 10}")
+		}
+		if !sliceEq(m[1]["age"], []int{11, 13}) {
+			t.Error("Expected age to be {11, 13}")
+		}
+	}
+}
+
+func TestFindAllStringIndexNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	if m0, m := re.FindAllStringIndexNamed("foo 42 bar 43", -1); m == nil {
+		t.Error("Expected match")
+	} else {
+		if !sliceEq(m0[0], []int{0, 6}) {
+			t.Error("Expected match to be {0, 6}")
+		}
+		if !sliceEq(m[0]["name"], []int{0, 3}) {
+			t.Error("Expected name to be {0, 3}")
+		}
+		if !sliceEq(m[0]["age"], []int{4, 6}) {
+			t.Error("Expected age to be {4, 6}")
+		}
+		if !sliceEq(m0[1], []int{7, 13}) {
+			t.Error("Expected match to be {7, 13}")
+		}
+		if !sliceEq(m[1]["name"], []int{7, 10}) {
+			t.Error("Expected name to be {7, 10}")
+		}
+		if !sliceEq(m[1]["age"], []int{11, 13}) {
+			t.Error("Expected age to be {11, 13}")
+		}
+	}
+}
+
+func TestNoCapture(t *testing.T) {
+	re := MustCompile(`(?:\w+) (\d+)`)
+	if m0, m := re.FindStringNamed("foo 42"); m == nil {
+		t.Error("Expected match")
+	} else {
+		if m0 != "foo 42" {
+			t.Error("Expected match to be foo 42")
+		}
+		if len(m) != 0 {
+			t.Error("Expected no named match")
+		}
+	}
+}
+
+func TestNested(t *testing.T) {
+	re := MustCompile(`(?P<a>(?:1(?:2)?)*)(?P<b>3)`)
+	if m0, m := re.FindStringNamed("1211121123"); m == nil {
+		t.Error("Expected match")
+	} else {
+		if m0 != "1211121123" {
+			t.Error("Expected match to be  1211121123")
+		}
+		if m["a"] != "121112112" {
+			t.Error("Expected a to be 121112112")
+		}
+		if m["b"] != "3" {
+			t.Error("Expected b to be 3")
+		}
+		if _, ok := m["2"]; ok {
+			t.Error("Expected no 2")
+		}
+	}
+}
+
+func TestParensInCharClass(t *testing.T) {
+	re := MustCompile(`(?P<paren>[()])(?P<rest>\w+)`)
+	if m0, m := re.FindStringNamed("(foo"); m == nil {
+		t.Error("Expected match")
+	} else {
+		if m0 != "(foo" {
+			t.Error("Expected match to be (foo")
+		}
+		if m["paren"] != "(" {
+			t.Error("Expected paren to be (")
+		}
+		if m["rest"] != "foo" {
+			t.Error("Expected rest to be foo")
+		}
+	}
+}
+
+func TestInlineFlagGroup(t *testing.T) {
+	re := MustCompile(`(?P<word>(?i:foo))`)
+	if m0, m := re.FindStringNamed("FOO"); m == nil {
+		t.Error("Expected match")
+	} else {
+		if m0 != "FOO" {
+			t.Error("Expected match to be FOO")
+		}
+		if m["word"] != "FOO" {
+			t.Error("Expected word to be FOO")
+		}
+	}
+	if _, m := re.FindStringNamed("bar"); m != nil {
+		t.Error("Expected no match")
+	}
+}
+
+func TestFromRegexp(t *testing.T) {
+	compiled := regexp.MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	re := FromRegexp(compiled)
+	if m0, m := re.FindStringNamed("foo 42"); m == nil {
+		t.Error("Expected match")
+	} else {
+		if m0 != "foo 42" {
+			t.Error("Expected match to be foo 42")
+		}
+		if m["name"] != "foo" {
+			t.Error("Expected name to be foo")
+		}
+		if m["age"] != "42" {
+			t.Error("Expected age to be 42")
+		}
+	}
+}
+
+func TestCompileWith(t *testing.T) {
+	re, err := CompileWith(StdlibBackend{}, `(?P<name>\w+) (?P<age>\d+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m0, m := re.FindStringNamed("foo 42"); m == nil {
+		t.Error("Expected match")
+	} else {
+		if m0 != "foo 42" {
+			t.Error("Expected match to be foo 42")
+		}
+		if m["name"] != "foo" {
+			t.Error("Expected name to be foo")
+		}
+		if m["age"] != "42" {
+			t.Error("Expected age to be 42")
+		}
+	}
+	// Built through CompileWith(StdlibBackend{}, ...), re's *regexp.Regexp
+	// embed should still be populated, so plain regexp methods work too.
+	if !re.MatchString("foo 42") {
+		t.Error("expected MatchString to report a match")
+	}
+}
+
+// stringOnlyBackend wraps a *regexp.Regexp but panics if any of its
+// []byte-taking methods are called, so a test built on it fails loudly if a
+// string-path method starts converting its input to []byte again instead of
+// calling the string-native method directly.
+type stringOnlyBackend struct{ *regexp.Regexp }
+
+func (stringOnlyBackend) FindSubmatch([]byte) [][]byte { panic("FindSubmatch called on string path") }
+func (stringOnlyBackend) FindSubmatchIndex([]byte) []int {
+	panic("FindSubmatchIndex called on string path")
+}
+func (stringOnlyBackend) FindAllSubmatch([]byte, int) [][][]byte {
+	panic("FindAllSubmatch called on string path")
+}
+func (stringOnlyBackend) FindAllSubmatchIndex([]byte, int) [][]int {
+	panic("FindAllSubmatchIndex called on string path")
+}
+
+type stringOnlyRegexpBackend struct{}
+
+func (stringOnlyRegexpBackend) Compile(pattern string) (CompiledBackend, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return stringOnlyBackend{re}, nil
+}
+
+// TestStringPathsAvoidByteConversion guards against FindStringNamed,
+// FindStringIndexNamed, FindAllStringNamed, FindStringNamedInto and
+// FindAllStringNamedFunc reverting to converting their string argument to
+// []byte and calling the byte-oriented CompiledBackend methods, which would
+// allocate a full copy of the input on every call.
+func TestStringPathsAvoidByteConversion(t *testing.T) {
+	re, err := CompileWith(stringOnlyRegexpBackend{}, `(?P<name>\w+) (?P<age>\d+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	re.FindStringNamed("foo 42")
+	re.FindStringIndexNamed("foo 42")
+	re.FindAllStringNamed("foo 42 bar 43", -1)
+	re.FindStringNamedInto("foo 42", make(map[string]string))
+	re.FindAllStringNamedFunc("foo 42 bar 43", -1, func(string, NamedGroups) bool { return true })
+}
+
+func TestReplaceAllStringNamedFunc(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	got := re.ReplaceAllStringNamedFunc("foo 42 bar 43", func(match string, groups map[string]string) string {
+		return groups["age"] + "/" + groups["name"]
+	})
+	if got != "42/foo 43/bar" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestReplaceAllNamedFunc(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	got := re.ReplaceAllNamedFunc([]byte("foo 42 bar 43"), func(match []byte, groups map[string][]byte) []byte {
+		return append(append([]byte{}, groups["age"]...), append([]byte("/"), groups["name"]...)...)
+	})
+	if string(got) != "42/foo 43/bar" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestExpandNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	src := []byte("foo 42")
+	_, groups := re.FindNamed(src)
+	got := ExpandNamed(nil, []byte(`$name is $age, matched "$0"`), src, groups)
+	if string(got) != `foo is 42, matched "foo 42"` {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestAppendNamed(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	dst := []byte("result: ")
+	got := re.AppendNamed(dst, []byte("${name}/${age}"), []byte("foo 42"))
+	if string(got) != "result: foo/42" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestFindStringNamedInto(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+)? (?P<age>\d+)`)
+	dst := make(map[string]string)
+	whole, ok := re.FindStringNamedInto("foo 42", dst)
+	if !ok {
+		t.Fatal("expected match")
+	}
+	if whole != "foo 42" || dst["name"] != "foo" || dst["age"] != "42" {
+		t.Errorf("got %q %+v", whole, dst)
+	}
+	// Reused across a second, differently-shaped match.
+	whole, ok = re.FindStringNamedInto(" 43", dst)
+	if !ok {
+		t.Fatal("expected match")
+	}
+	if whole != " 43" || dst["name"] != "" || dst["age"] != "43" {
+		t.Errorf("got %q %+v", whole, dst)
+	}
+}
+
+func TestFindStringNamedIntoNoMatch(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	dst := make(map[string]string)
+	if _, ok := re.FindStringNamedInto("nope", dst); ok {
+		t.Error("expected no match")
+	}
+}
+
+func TestFindAllStringNamedFunc(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	var wholes []string
+	var names []string
+	re.FindAllStringNamedFunc("foo 42 bar 43", -1, func(whole string, groups NamedGroups) bool {
+		wholes = append(wholes, whole)
+		name, ok := groups.Get("name")
+		if !ok {
+			t.Error("expected name to participate")
+		}
+		names = append(names, name)
+		if groups.Len() != 2 {
+			t.Errorf("expected 2 named groups, got %d", groups.Len())
+		}
+		return true
+	})
+	if !sliceEq(wholes, []string{"foo 42", "bar 43"}) {
+		t.Errorf("got %v", wholes)
+	}
+	if !sliceEq(names, []string{"foo", "bar"}) {
+		t.Errorf("got %v", names)
+	}
+}
+
+func TestFindAllStringNamedFuncStopsEarly(t *testing.T) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	count := 0
+	re.FindAllStringNamedFunc("foo 42 bar 43 baz 44", -1, func(whole string, groups NamedGroups) bool {
+		count++
+		return count < 2
+	})
+	if count != 2 {
+		t.Errorf("expected to stop after 2 calls, got %d", count)
+	}
+}
+
+func BenchmarkFindStringNamed(b *testing.B) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	for i := 0; i < b.N; i++ {
+		re.FindStringNamed("foo 42")
+	}
+}
+
+func BenchmarkFindStringNamedInto(b *testing.B) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	dst := make(map[string]string)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		re.FindStringNamedInto("foo 42", dst)
+	}
+}
+
+func BenchmarkFindAllStringNamed(b *testing.B) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	s := "foo 42 bar 43 baz 44 qux 45"
+	for i := 0; i < b.N; i++ {
+		re.FindAllStringNamed(s, -1)
+	}
+}
+
+func BenchmarkFindAllStringNamedFunc(b *testing.B) {
+	re := MustCompile(`(?P<name>\w+) (?P<age>\d+)`)
+	s := "foo 42 bar 43 baz 44 qux 45"
+	for i := 0; i < b.N; i++ {
+		re.FindAllStringNamedFunc(s, -1, func(whole string, groups NamedGroups) bool {
+			return true
+		})
+	}
+}